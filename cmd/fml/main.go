@@ -1,9 +1,12 @@
 package main
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
 	"github.com/mitlibraries/fml"
 	"github.com/urfave/cli"
+	"io"
 	"os"
 )
 
@@ -33,6 +36,148 @@ func main() {
 				return nil
 			},
 		},
+		{
+			Name:      "convert",
+			Usage:     "Convert MARC records between ISO 2709 binary and MARCXML",
+			ArgsUsage: "[file]",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "to",
+					Usage: "output format: xml or marc",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				to := c.String("to")
+				if to != "xml" && to != "marc" {
+					return errors.New("--to must be xml or marc")
+				}
+				file, err := os.Open(c.Args().Get(0))
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+
+				r := bufio.NewReader(file)
+				first, err := r.Peek(1)
+				if err != nil && err != io.EOF {
+					return err
+				}
+
+				var nextRecord func() (fml.Record, bool, error)
+				if len(first) > 0 && first[0] == '<' {
+					it := fml.NewMarcXMLIterator(r)
+					nextRecord = func() (fml.Record, bool, error) {
+						if !it.Next() {
+							return fml.Record{}, false, it.Err()
+						}
+						record, err := it.Value()
+						return record, true, err
+					}
+				} else {
+					it := fml.NewMarcIterator(r)
+					nextRecord = func() (fml.Record, bool, error) {
+						if !it.Next() {
+							return fml.Record{}, false, it.Err()
+						}
+						record, err := it.Value()
+						return record, true, err
+					}
+				}
+
+				var cw *fml.MarcXMLCollectionWriter
+				if to == "xml" {
+					cw, err = fml.NewMarcXMLCollectionWriter(os.Stdout)
+					if err != nil {
+						return err
+					}
+				}
+
+				for {
+					record, ok, err := nextRecord()
+					if err != nil {
+						return err
+					}
+					if !ok {
+						break
+					}
+					switch to {
+					case "xml":
+						if err := cw.WriteRecord(record); err != nil {
+							return err
+						}
+					case "marc":
+						if len(record.Data) > 0 {
+							os.Stdout.Write(record.Data)
+						} else if _, err := record.WriteTo(os.Stdout); err != nil {
+							return err
+						}
+					}
+				}
+				if cw != nil {
+					return cw.Close()
+				}
+				return nil
+			},
+		},
+		{
+			Name:      "cat",
+			Usage:     "Print MARC records as breaker/mnemonic text",
+			ArgsUsage: "[file]",
+			Action: func(c *cli.Context) error {
+				file, err := os.Open(c.Args().Get(0))
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+
+				it := fml.NewMarcIterator(file)
+				for it.Next() {
+					record, err := it.Value()
+					if err != nil {
+						return err
+					}
+					if err := fml.WriteBreaker(os.Stdout, record); err != nil {
+						return err
+					}
+					fmt.Println(`\`)
+				}
+				return it.Err()
+			},
+		},
+		{
+			Name:  "unbreak",
+			Usage: "Read breaker/mnemonic text from stdin and write binary MARC to stdout",
+			Action: func(c *cli.Context) error {
+				it := fml.NewMarcBreakerIterator(os.Stdin)
+				for it.Next() {
+					record, err := it.Value()
+					if err != nil {
+						return err
+					}
+					if _, err := record.WriteTo(os.Stdout); err != nil {
+						return err
+					}
+				}
+				return it.Err()
+			},
+		},
+		{
+			Name:  "build",
+			Usage: "Read MARC-in-JSON from stdin and write binary MARC (ISO 2709) to stdout",
+			Action: func(c *cli.Context) error {
+				it := fml.NewMarcJSONIterator(os.Stdin)
+				for it.Next() {
+					record, err := it.Value()
+					if err != nil {
+						return err
+					}
+					if _, err := record.WriteTo(os.Stdout); err != nil {
+						return err
+					}
+				}
+				return it.Err()
+			},
+		},
 	}
 	err := app.Run(os.Args)
 	if err != nil {
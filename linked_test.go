@@ -0,0 +1,90 @@
+package fml
+
+import (
+	"strings"
+	"testing"
+)
+
+func recordWithLinkedFields() Record {
+	r := NewRecord(Leader{Type: 'a'})
+	r.AddDataField("245", "1", "0",
+		SubField{Code: "a", Value: "Romanized title /"},
+		SubField{Code: "6", Value: "880-01"},
+	)
+	r.AddDataField("880", "1", "0",
+		SubField{Code: "6", Value: "245-01"},
+		SubField{Code: "a", Value: "原題 /"},
+	)
+	return *r
+}
+
+func TestLinkedFields(t *testing.T) {
+	r := recordWithLinkedFields()
+	base := r.DataField("245")[0]
+
+	linked := r.LinkedFields(base)
+	if len(linked) != 1 {
+		t.Fatal("Expected 1, got", len(linked))
+	}
+	if linked[0].Tag != "880" {
+		t.Error("Expected 880, got", linked[0].Tag)
+	}
+
+	alt := r.DataField("880")[0]
+	backLinked := r.LinkedFields(alt)
+	if len(backLinked) != 1 || backLinked[0].Tag != "245" {
+		t.Error("Expected to resolve back to 245, got", backLinked)
+	}
+}
+
+func TestLinkedFieldsScriptSuffix(t *testing.T) {
+	r := NewRecord(Leader{Type: 'a'})
+	r.AddDataField("245", "1", "0",
+		SubField{Code: "a", Value: "Romanized title /"},
+		SubField{Code: "6", Value: "880-01"},
+	)
+	r.AddDataField("880", "1", "0",
+		SubField{Code: "6", Value: "245-01/(2/r"},
+		SubField{Code: "a", Value: "العنوان /"},
+	)
+
+	base := r.DataField("245")[0]
+	linked := r.LinkedFields(base)
+	if len(linked) != 1 || linked[0].Tag != "880" {
+		t.Fatal("Expected to resolve 880 despite script-identifier suffix, got", linked)
+	}
+}
+
+func TestFilterLinked(t *testing.T) {
+	r := recordWithLinkedFields()
+	sfs := r.Filter("245a+")
+	if len(sfs) != 1 {
+		t.Fatal("Expected 1, got", len(sfs))
+	}
+	if got := strings.Join(sfs[0], " "); got != "Romanized title / 原題 /" {
+		t.Error("Expected romanized and vernacular together, got", got)
+	}
+}
+
+func TestSubFieldGroups(t *testing.T) {
+	df := DataField{
+		Tag: "505",
+		SubFields: []SubField{
+			{Code: "a", Value: "Part one /"},
+			{Code: "r", Value: "Author one --"},
+			{Code: "a", Value: "Part two /"},
+			{Code: "r", Value: "Author two."},
+		},
+	}
+
+	groups := df.SubFieldGroups("a")
+	if len(groups) != 2 {
+		t.Fatal("Expected 2, got", len(groups))
+	}
+	if len(groups[0]) != 2 || groups[0][1].Value != "Author one --" {
+		t.Error("Expected first group to include its $r, got", groups[0])
+	}
+	if len(groups[1]) != 2 || groups[1][1].Value != "Author two." {
+		t.Error("Expected second group to include its $r, got", groups[1])
+	}
+}
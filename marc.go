@@ -120,6 +120,83 @@ func (d DataField) SubField(subfield ...string) []SubField {
 	return fields
 }
 
+// SubFieldGroups splits a data field's subfields into repeating groups,
+// starting a new group each time a subfield with the given anchor code is
+// seen. This mirrors fields like 505 (formatted contents notes, anchored
+// on $a) or 700 (name/title analytics, anchored on $t), where a single
+// field packs several logical entries that would otherwise have to be
+// split by hand. Subfields before the first anchor occurrence are
+// dropped, since they don't belong to any group.
+func (d DataField) SubFieldGroups(anchor string) [][]SubField {
+	var groups [][]SubField
+	var current []SubField
+	for _, f := range d.SubFields {
+		if f.Code == anchor {
+			if current != nil {
+				groups = append(groups, current)
+			}
+			current = []SubField{f}
+			continue
+		}
+		if current != nil {
+			current = append(current, f)
+		}
+	}
+	if current != nil {
+		groups = append(groups, current)
+	}
+	return groups
+}
+
+// linkTag splits a $6 linkage value such as "880-01" into its target tag
+// and occurrence number. Occurrence numbers may carry a trailing
+// script-identifier suffix, e.g. "01/(2/r", which is stripped since it
+// does not participate in matching a field to its counterpart.
+func linkTag(value string) (tag, occurrence string) {
+	parts := strings.SplitN(value, "-", 2)
+	if len(parts) != 2 {
+		return "", ""
+	}
+	occurrence = parts[1]
+	if i := strings.Index(occurrence, "/"); i != -1 {
+		occurrence = occurrence[:i]
+	}
+	return parts[0], occurrence
+}
+
+// LinkedFields returns the data fields linked to d via the $6
+// linkage subfield, most commonly the 880 alternate-graphic-representation
+// fields linked to a romanized base field, or vice versa. Two fields are
+// considered linked when each one's $6 names the other's tag and they
+// share the same occurrence number, e.g. a 245 field with $6 "880-01" is
+// linked to an 880 field with $6 "245-01".
+func (r Record) LinkedFields(d DataField) []DataField {
+	links := d.SubField("6")
+	if len(links) == 0 {
+		return nil
+	}
+	tag, occurrence := linkTag(links[0].Value)
+	if tag == "" {
+		return nil
+	}
+
+	var linked []DataField
+	for _, f := range r.Fields {
+		field, ok := f.(DataField)
+		if !ok || field.Tag != tag {
+			continue
+		}
+		for _, sf := range field.SubField("6") {
+			t, occ := linkTag(sf.Value)
+			if t == d.Tag && occ == occurrence {
+				linked = append(linked, field)
+				break
+			}
+		}
+	}
+	return linked
+}
+
 func (d DataField) matches(tag string, ind1 string, ind2 string) bool {
 	t := d.Tag == tag
 	i1 := ind1 == "*" || d.Indicator1 == ind1
@@ -133,10 +210,14 @@ func (d DataField) matches(tag string, ind1 string, ind2 string) bool {
 // for example: "245ac", "650x" or "100". Filtering for indicators can be
 // done by including the two desired indicators between pipes after the tag.
 // An * character can be used for any inidicator, for example: "245|*1|ac"
-// or 650|01|x.
+// or 650|01|x. A trailing + includes each matched field's linked 880
+// counterparts (see LinkedFields) grouped in with its own values, for
+// example "245a+" returns the romanized and vernacular title together.
 func (r Record) Filter(query ...string) [][]string {
 	var res [][]string
 	for _, q := range query {
+		withLinked := strings.HasSuffix(q, "+")
+		q = strings.TrimSuffix(q, "+")
 		tag := q[:3]
 		for _, field := range r.Fields {
 			var values []string
@@ -166,6 +247,19 @@ func (r Record) Filter(query ...string) [][]string {
 							values = append(values, sf.Value)
 						}
 					}
+					if withLinked {
+						for _, linked := range r.LinkedFields(f) {
+							if len(subs) != 0 {
+								for _, sf := range linked.SubField(strings.Split(subs, "")...) {
+									values = append(values, sf.Value)
+								}
+							} else {
+								for _, sf := range linked.SubFields {
+									values = append(values, sf.Value)
+								}
+							}
+						}
+					}
 					if len(values) > 0 {
 						res = append(res, values)
 					}
@@ -197,15 +291,7 @@ func (m *MarcIterator) Err() error {
 func (m *MarcIterator) scanIntoRecord(bytes []byte) (Record, error) {
 	rec := Record{}
 	rec.Data = append([]byte(nil), bytes...)
-	rec.Leader = Leader{
-		Status:        bytes[5],
-		Type:          bytes[6],
-		BibLevel:      bytes[7],
-		Control:       bytes[8],
-		EncodingLevel: bytes[17],
-		Form:          bytes[18],
-		Multipart:     bytes[19],
-	}
+	rec.Leader = leaderFromBytes(bytes)
 
 	start, err := strconv.Atoi(string(bytes[12:17]))
 	if err != nil {
@@ -251,6 +337,41 @@ func NewMarcIterator(r io.Reader) *MarcIterator {
 	return &MarcIterator{scanner}
 }
 
+// leaderFromBytes extracts the Leader fields from the leading bytes of a
+// record. It is shared by every format that can produce a Leader from raw
+// bytes, such as the MARCXML reader.
+func leaderFromBytes(b []byte) Leader {
+	return Leader{
+		Status:        b[5],
+		Type:          b[6],
+		BibLevel:      b[7],
+		Control:       b[8],
+		EncodingLevel: b[17],
+		Form:          b[18],
+		Multipart:     b[19],
+	}
+}
+
+// leaderBytes renders a Record's Leader back into a 24-byte leader. When
+// the Record still carries its original source bytes (Data), those are
+// used so that computed fields such as the record length and base address
+// survive the round trip. Otherwise the computed fields are left blank, as
+// MARCXML leaders commonly omit them.
+func leaderBytes(r Record) []byte {
+	if len(r.Data) >= 24 {
+		return append([]byte(nil), r.Data[:24]...)
+	}
+	b := []byte(strings.Repeat(" ", 24))
+	b[5] = r.Leader.Status
+	b[6] = r.Leader.Type
+	b[7] = r.Leader.BibLevel
+	b[8] = r.Leader.Control
+	b[17] = r.Leader.EncodingLevel
+	b[18] = r.Leader.Form
+	b[19] = r.Leader.Multipart
+	return b
+}
+
 func makeDataField(tag string, data []byte) (DataField, error) {
 	d := DataField{}
 	d.Tag = tag
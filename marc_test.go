@@ -13,7 +13,7 @@ func TestRecord(t *testing.T) {
 	}
 	iter := NewMarcIterator(f)
 	_ = iter.Next()
-	r := iter.Value()
+	r, _ := iter.Value()
 	t.Run("ControlNum", func(t *testing.T) {
 		if r.ControlNum() != "92005291" {
 			t.Error("Expected 92005291, got", r.ControlNum())
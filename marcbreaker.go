@@ -0,0 +1,143 @@
+package fml
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// defaultBreakerDelimiter is the subfield delimiter used by most breaker
+// tools, including MarcEdit.
+const defaultBreakerDelimiter = '$'
+
+// MarcBreakerIterator will iterate over a set of MARC records encoded as
+// breaker/mnemonic text using the Next() and Value() methods. Records are
+// separated by a line containing only `\`. Use NewMarcBreakerIterator or
+// NewMarcBreakerIteratorDelim to create a MarcBreakerIterator.
+type MarcBreakerIterator struct {
+	scanner   *bufio.Scanner
+	delimiter rune
+	lines     []string
+	err       error
+	done      bool
+}
+
+// NewMarcBreakerIterator creates and returns a new instance of a
+// MarcBreakerIterator using `$` as the subfield delimiter. This function
+// should be used to create a MarcBreakerIterator rather than
+// instantiating one yourself.
+func NewMarcBreakerIterator(r io.Reader) *MarcBreakerIterator {
+	return NewMarcBreakerIteratorDelim(r, defaultBreakerDelimiter)
+}
+
+// NewMarcBreakerIteratorDelim is like NewMarcBreakerIterator but lets the
+// caller choose the subfield delimiter rune, for tools (such as some
+// MarcEdit exports) that use `‡` instead of `$`.
+func NewMarcBreakerIteratorDelim(r io.Reader, delimiter rune) *MarcBreakerIterator {
+	return &MarcBreakerIterator{scanner: bufio.NewScanner(r), delimiter: delimiter}
+}
+
+// Next advances the MarcBreakerIterator to the next record, which will be
+// available through the Value method. It returns false when the
+// MarcBreakerIterator has reached the end of the input or has encountered
+// an error. Any error will be accessible from the Err method.
+func (m *MarcBreakerIterator) Next() bool {
+	if m.done {
+		return false
+	}
+	m.lines = m.lines[:0]
+	for m.scanner.Scan() {
+		line := m.scanner.Text()
+		if line == `\` {
+			return true
+		}
+		m.lines = append(m.lines, line)
+	}
+	if err := m.scanner.Err(); err != nil {
+		m.err = err
+		m.done = true
+		return false
+	}
+	m.done = true
+	return len(m.lines) > 0
+}
+
+// Value returns the current Record or the MarcBreakerIterator.
+func (m *MarcBreakerIterator) Value() (Record, error) {
+	return parseBreakerLines(m.lines, m.delimiter)
+}
+
+// Err will return the first error encountered by the MarcBreakerIterator.
+func (m *MarcBreakerIterator) Err() error {
+	return m.err
+}
+
+func parseBreakerLines(lines []string, delimiter rune) (Record, error) {
+	rec := Record{}
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "=") {
+			continue
+		}
+		line = line[1:]
+		if len(line) < 5 {
+			return rec, fmt.Errorf("malformed breaker line %q", line)
+		}
+		tag := line[:3]
+		rest := line[5:]
+
+		if tag == "LDR" {
+			leader := rest
+			if len(leader) < 24 {
+				leader += strings.Repeat(" ", 24-len(leader))
+			}
+			rec.Leader = leaderFromBytes([]byte(leader))
+			continue
+		}
+		if strings.HasPrefix(tag, "00") {
+			rec.Fields = append(rec.Fields, ControlField{Tag: tag, Value: rest})
+			continue
+		}
+		if len(rest) < 2 {
+			return rec, fmt.Errorf("malformed data field line %q", line)
+		}
+		df := DataField{Tag: tag, Indicator1: string(rest[0]), Indicator2: string(rest[1])}
+		for _, part := range strings.Split(rest[2:], string(delimiter)) {
+			if part == "" {
+				continue
+			}
+			r := []rune(part)
+			df.SubFields = append(df.SubFields, SubField{Code: string(r[0]), Value: string(r[1:])})
+		}
+		rec.Fields = append(rec.Fields, df)
+	}
+	return rec, nil
+}
+
+// WriteBreaker writes a single Record to w as breaker/mnemonic text, using
+// `$` as the subfield delimiter.
+func WriteBreaker(w io.Writer, r Record) error {
+	return WriteBreakerDelim(w, r, defaultBreakerDelimiter)
+}
+
+// WriteBreakerDelim is like WriteBreaker but lets the caller choose the
+// subfield delimiter rune.
+func WriteBreakerDelim(w io.Writer, r Record, delimiter rune) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "=LDR  %s\n", leaderBytes(r))
+	for _, f := range r.Fields {
+		switch field := f.(type) {
+		case ControlField:
+			fmt.Fprintf(&buf, "=%s  %s\n", field.Tag, field.Value)
+		case DataField:
+			fmt.Fprintf(&buf, "=%s  %s%s", field.Tag, field.Indicator1, field.Indicator2)
+			for _, sf := range field.SubFields {
+				fmt.Fprintf(&buf, "%c%s%s", delimiter, sf.Code, sf.Value)
+			}
+			buf.WriteByte('\n')
+		}
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,100 @@
+package fml
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const breakerFixture = "=LDR  00000cam a2200000 a 4500\n" +
+	"=001  92005291\n" +
+	"=245  10$aTitle :$bsubtitle /$cauthor.\n" +
+	"\\\n" +
+	"=LDR  a\n" +
+	"=001  92005292\n"
+
+func TestMarcBreakerIterator(t *testing.T) {
+	iter := NewMarcBreakerIterator(strings.NewReader(breakerFixture))
+
+	t.Run("First record", func(t *testing.T) {
+		if !iter.Next() {
+			t.Fatal("expected a record")
+		}
+		r, err := iter.Value()
+		if err != nil {
+			t.Error(err)
+		}
+		if r.ControlNum() != "92005291" {
+			t.Error("Expected 92005291, got", r.ControlNum())
+		}
+		sfs := r.Filter("245abc")
+		if len(sfs) != 1 || strings.Join(sfs[0], " ") != "Title : subtitle / author." {
+			t.Error("Expected Title : subtitle / author., got", sfs)
+		}
+	})
+
+	t.Run("Last record without trailing separator", func(t *testing.T) {
+		if !iter.Next() {
+			t.Fatal("expected a second record")
+		}
+		r, err := iter.Value()
+		if err != nil {
+			t.Error(err)
+		}
+		if r.ControlNum() != "92005292" {
+			t.Error("Expected 92005292, got", r.ControlNum())
+		}
+		if r.Leader.Type != 0x20 {
+			t.Error("Expected padded space, got", r.Leader.Type)
+		}
+	})
+
+	t.Run("End of input", func(t *testing.T) {
+		if iter.Next() {
+			t.Error("expected no more records")
+		}
+	})
+}
+
+func TestWriteBreaker(t *testing.T) {
+	rec := buildSampleRecord()
+
+	var buf bytes.Buffer
+	if err := WriteBreaker(&buf, *rec); err != nil {
+		t.Fatal(err)
+	}
+
+	iter := NewMarcBreakerIterator(&buf)
+	if !iter.Next() {
+		t.Fatal("expected to read back the written record")
+	}
+	out, err := iter.Value()
+	if err != nil {
+		t.Error(err)
+	}
+	if out.ControlNum() != "92005291" {
+		t.Error("Expected 92005291, got", out.ControlNum())
+	}
+}
+
+func TestWriteBreakerDelim(t *testing.T) {
+	rec := buildSampleRecord()
+
+	var buf bytes.Buffer
+	if err := WriteBreakerDelim(&buf, *rec, '‡'); err != nil {
+		t.Fatal(err)
+	}
+
+	iter := NewMarcBreakerIteratorDelim(&buf, '‡')
+	if !iter.Next() {
+		t.Fatal("expected to read back the written record")
+	}
+	out, err := iter.Value()
+	if err != nil {
+		t.Error(err)
+	}
+	sfs := out.Filter("245ab")
+	if len(sfs) != 1 || strings.Join(sfs[0], " ") != "Title : subtitle /" {
+		t.Error("Expected Title : subtitle /, got", sfs)
+	}
+}
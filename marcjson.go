@@ -0,0 +1,128 @@
+package fml
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// MarcJSONIterator will iterate over a set of MARC records encoded as
+// newline-delimited MARC-in-JSON using the Next() and Value() methods.
+// Use the NewMarcJSONIterator function to create a MarcJSONIterator.
+type MarcJSONIterator struct {
+	scanner *bufio.Scanner
+}
+
+// NewMarcJSONIterator creates and returns a new instance of a
+// MarcJSONIterator. This function should be used to create a
+// MarcJSONIterator rather than instantiating one yourself. It expects one
+// MARC-in-JSON record per line.
+func NewMarcJSONIterator(r io.Reader) *MarcJSONIterator {
+	return &MarcJSONIterator{scanner: bufio.NewScanner(r)}
+}
+
+// Next advances the MarcJSONIterator to the next record, which will be
+// available through the Value method. It returns false when the
+// MarcJSONIterator has reached the end of the input or has encountered an
+// error. Any error will be accessible from the Err method.
+func (m *MarcJSONIterator) Next() bool {
+	return m.scanner.Scan()
+}
+
+// Value returns the current Record or the MarcJSONIterator.
+func (m *MarcJSONIterator) Value() (Record, error) {
+	var j marcJSONRecord
+	if err := json.Unmarshal(m.scanner.Bytes(), &j); err != nil {
+		return Record{}, err
+	}
+	return j.toRecord()
+}
+
+// Err will return the first error encountered by the MarcJSONIterator.
+func (m *MarcJSONIterator) Err() error {
+	return m.scanner.Err()
+}
+
+// marcJSONRecord mirrors the "MARC-in-JSON" structure: a leader string and
+// a list of fields, each a single-key object mapping a tag to either a
+// control field string or a data field object.
+type marcJSONRecord struct {
+	Leader string                       `json:"leader"`
+	Fields []map[string]json.RawMessage `json:"fields"`
+}
+
+// marcJSONDataField is the object form used for data fields, keyed by tag
+// in the enclosing field object.
+type marcJSONDataField struct {
+	Ind1      string              `json:"ind1"`
+	Ind2      string              `json:"ind2"`
+	SubFields []map[string]string `json:"subfields"`
+}
+
+func (j marcJSONRecord) toRecord() (Record, error) {
+	rec := Record{}
+	leader := j.Leader
+	if len(leader) < 24 {
+		leader += strings.Repeat(" ", 24-len(leader))
+	}
+	rec.Leader = leaderFromBytes([]byte(leader))
+
+	for _, field := range j.Fields {
+		for tag, raw := range field {
+			var value string
+			if err := json.Unmarshal(raw, &value); err == nil {
+				rec.Fields = append(rec.Fields, ControlField{Tag: tag, Value: value})
+				continue
+			}
+			var df marcJSONDataField
+			if err := json.Unmarshal(raw, &df); err != nil {
+				return rec, err
+			}
+			d := DataField{Tag: tag, Indicator1: df.Ind1, Indicator2: df.Ind2}
+			for _, sf := range df.SubFields {
+				for code, val := range sf {
+					d.SubFields = append(d.SubFields, SubField{Code: code, Value: val})
+				}
+			}
+			rec.Fields = append(rec.Fields, d)
+		}
+	}
+	return rec, nil
+}
+
+// WriteMarcJSON writes a single Record to w as one line of compact
+// MARC-in-JSON, suitable for JSONL output.
+func WriteMarcJSON(w io.Writer, r Record) error {
+	j := marcJSONRecord{Leader: string(leaderBytes(r))}
+	for _, f := range r.Fields {
+		switch field := f.(type) {
+		case ControlField:
+			raw, err := json.Marshal(field.Value)
+			if err != nil {
+				return err
+			}
+			j.Fields = append(j.Fields, map[string]json.RawMessage{field.Tag: raw})
+		case DataField:
+			df := marcJSONDataField{Ind1: field.Indicator1, Ind2: field.Indicator2}
+			for _, sf := range field.SubFields {
+				df.SubFields = append(df.SubFields, map[string]string{sf.Code: sf.Value})
+			}
+			raw, err := json.Marshal(df)
+			if err != nil {
+				return err
+			}
+			j.Fields = append(j.Fields, map[string]json.RawMessage{field.Tag: raw})
+		}
+	}
+
+	b, err := json.Marshal(j)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(b); err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
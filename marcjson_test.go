@@ -0,0 +1,86 @@
+package fml
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const jsonFixture = `{"leader":"00000cam a2200000 a 4500","fields":[{"001":"92005291"},{"245":{"ind1":"1","ind2":"0","subfields":[{"a":"Title :"},{"b":"subtitle /"}]}}]}
+{"leader":"a","fields":[{"001":"92005292"}]}
+`
+
+func TestMarcJSONIterator(t *testing.T) {
+	iter := NewMarcJSONIterator(strings.NewReader(jsonFixture))
+
+	t.Run("First record", func(t *testing.T) {
+		if !iter.Next() {
+			t.Fatal("expected a record")
+		}
+		r, err := iter.Value()
+		if err != nil {
+			t.Error(err)
+		}
+		if r.ControlNum() != "92005291" {
+			t.Error("Expected 92005291, got", r.ControlNum())
+		}
+		sfs := r.Filter("245ab")
+		if len(sfs) != 1 || strings.Join(sfs[0], " ") != "Title : subtitle /" {
+			t.Error("Expected Title : subtitle /, got", sfs)
+		}
+	})
+
+	t.Run("Short leader is padded", func(t *testing.T) {
+		if !iter.Next() {
+			t.Fatal("expected a second record")
+		}
+		r, err := iter.Value()
+		if err != nil {
+			t.Error(err)
+		}
+		if r.Leader.Type != 0x20 {
+			t.Error("Expected padded space, got", r.Leader.Type)
+		}
+	})
+
+	t.Run("End of input", func(t *testing.T) {
+		if iter.Next() {
+			t.Error("expected no more records")
+		}
+		if iter.Err() != nil {
+			t.Error(iter.Err())
+		}
+	})
+}
+
+func TestWriteMarcJSON(t *testing.T) {
+	rec := Record{
+		Leader: Leader{Type: 'a'},
+		Fields: []interface{}{
+			ControlField{Tag: "001", Value: "92005291"},
+			DataField{
+				Tag:        "245",
+				Indicator1: "1",
+				Indicator2: "0",
+				SubFields:  []SubField{{Code: "a", Value: "Title /"}},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteMarcJSON(&buf, rec); err != nil {
+		t.Fatal(err)
+	}
+
+	iter := NewMarcJSONIterator(&buf)
+	if !iter.Next() {
+		t.Fatal("expected to read back the written record")
+	}
+	r, err := iter.Value()
+	if err != nil {
+		t.Error(err)
+	}
+	if r.ControlNum() != "92005291" {
+		t.Error("Expected 92005291, got", r.ControlNum())
+	}
+}
@@ -0,0 +1,167 @@
+package fml
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+const ft = 0x1e // End of field
+
+// NewRecord creates and returns a new, empty Record with the given
+// Leader. Use AddControlField and AddDataField to populate it, then
+// Marshal or WriteTo to serialize it to binary MARC.
+func NewRecord(leader Leader) *Record {
+	return &Record{Leader: leader}
+}
+
+// AddControlField appends a control field to the Record.
+func (r *Record) AddControlField(tag, value string) {
+	r.Fields = append(r.Fields, ControlField{Tag: tag, Value: value})
+}
+
+// AddDataField appends a data field, with its indicators and subfields,
+// to the Record.
+func (r *Record) AddDataField(tag, ind1, ind2 string, subfields ...SubField) {
+	r.Fields = append(r.Fields, DataField{
+		Tag:        tag,
+		Indicator1: ind1,
+		Indicator2: ind2,
+		SubFields:  subfields,
+	})
+}
+
+// RemoveField removes every control and data field matching any of the
+// given tags.
+func (r *Record) RemoveField(tag ...string) {
+	match := func(t string) bool {
+		for _, x := range tag {
+			if x == t {
+				return true
+			}
+		}
+		return false
+	}
+
+	fields := r.Fields[:0]
+	for _, f := range r.Fields {
+		switch field := f.(type) {
+		case ControlField:
+			if !match(field.Tag) {
+				fields = append(fields, f)
+			}
+		case DataField:
+			if !match(field.Tag) {
+				fields = append(fields, f)
+			}
+		}
+	}
+	r.Fields = fields
+}
+
+// Marshal serializes the Record to binary MARC (ISO 2709), recomputing
+// the leader's record length and base address and building the field
+// directory from scratch.
+func (r *Record) Marshal() ([]byte, error) {
+	var dir bytes.Buffer
+	var data bytes.Buffer
+
+	for _, f := range r.Fields {
+		tag, fieldBytes, err := marshalField(f)
+		if err != nil {
+			return nil, err
+		}
+		if len(fieldBytes) > 9999 {
+			return nil, fmt.Errorf("field %q is too long to encode in the directory", tag)
+		}
+		if data.Len() > 99999 {
+			return nil, fmt.Errorf("record is too long to encode in the directory")
+		}
+		fmt.Fprintf(&dir, "%s%04d%05d", tag, len(fieldBytes), data.Len())
+		data.Write(fieldBytes)
+	}
+	dir.WriteByte(ft)
+
+	base := 24 + dir.Len()
+	total := base + data.Len() + 1 // +1 for the record terminator
+	if total > 99999 {
+		return nil, fmt.Errorf("record length %d exceeds the 5-digit leader field", total)
+	}
+
+	leader := leaderBytes(*r)
+	copy(leader[0:5], fmt.Sprintf("%05d", total))
+	copy(leader[10:12], "22")
+	copy(leader[12:17], fmt.Sprintf("%05d", base))
+	copy(leader[20:24], "4500")
+
+	var out bytes.Buffer
+	out.Write(leader)
+	out.Write(dir.Bytes())
+	out.Write(data.Bytes())
+	out.WriteByte(rt)
+	return out.Bytes(), nil
+}
+
+// WriteTo writes the Record to w as binary MARC (ISO 2709).
+func (r *Record) WriteTo(w io.Writer) (int64, error) {
+	b, err := r.Marshal()
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+func marshalField(f interface{}) (tag string, data []byte, err error) {
+	switch field := f.(type) {
+	case ControlField:
+		if err := validateTag(field.Tag); err != nil {
+			return "", nil, err
+		}
+		return field.Tag, append([]byte(field.Value), ft), nil
+	case DataField:
+		if err := validateTag(field.Tag); err != nil {
+			return "", nil, err
+		}
+		if err := validateIndicator(field.Indicator1); err != nil {
+			return "", nil, err
+		}
+		if err := validateIndicator(field.Indicator2); err != nil {
+			return "", nil, err
+		}
+		var b bytes.Buffer
+		b.WriteString(field.Indicator1)
+		b.WriteString(field.Indicator2)
+		for _, sf := range field.SubFields {
+			if len(sf.Code) != 1 {
+				return "", nil, fmt.Errorf("subfield code %q must be a single byte", sf.Code)
+			}
+			b.WriteByte(st)
+			b.WriteString(sf.Code)
+			b.WriteString(sf.Value)
+		}
+		b.WriteByte(ft)
+		return field.Tag, b.Bytes(), nil
+	default:
+		return "", nil, fmt.Errorf("unsupported field type %T", f)
+	}
+}
+
+func validateTag(tag string) error {
+	if len(tag) != 3 {
+		return fmt.Errorf("tag %q must be three digits", tag)
+	}
+	for _, c := range tag {
+		if c < '0' || c > '9' {
+			return fmt.Errorf("tag %q must be three digits", tag)
+		}
+	}
+	return nil
+}
+
+func validateIndicator(ind string) error {
+	if len(ind) != 1 {
+		return fmt.Errorf("indicator %q must be a single byte", ind)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package fml
+
+import (
+	"bytes"
+	"testing"
+)
+
+func buildSampleRecord() *Record {
+	r := NewRecord(Leader{Type: 'a', BibLevel: 'm'})
+	r.AddControlField("001", "92005291")
+	r.AddDataField("245", "1", "0",
+		SubField{Code: "a", Value: "Title :"},
+		SubField{Code: "b", Value: "subtitle /"},
+	)
+	return r
+}
+
+func TestRecordMarshalRoundTrip(t *testing.T) {
+	r := buildSampleRecord()
+	b, err := r.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	iter := NewMarcIterator(bytes.NewReader(b))
+	if !iter.Next() {
+		t.Fatal("expected to read back the marshaled record")
+	}
+	out, err := iter.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if out.ControlNum() != "92005291" {
+		t.Error("Expected 92005291, got", out.ControlNum())
+	}
+	if out.Leader.Type != 'a' || out.Leader.BibLevel != 'm' {
+		t.Error("Leader did not round-trip, got", out.Leader)
+	}
+	sfs := out.Filter("245ab")
+	if len(sfs) != 1 {
+		t.Fatal("Expected 1, got", len(sfs))
+	}
+	if got := sfs[0][0] + " " + sfs[0][1]; got != "Title : subtitle /" {
+		t.Error("Expected Title : subtitle /, got", got)
+	}
+}
+
+func TestRecordRemoveField(t *testing.T) {
+	r := buildSampleRecord()
+	r.RemoveField("245")
+	if len(r.DataField("245")) != 0 {
+		t.Error("Expected 245 to be removed")
+	}
+	if len(r.ControlField("001")) != 1 {
+		t.Error("Expected 001 to remain")
+	}
+}
+
+func TestRecordMarshalInvalidTag(t *testing.T) {
+	r := NewRecord(Leader{})
+	r.AddControlField("abc", "nope")
+	if _, err := r.Marshal(); err == nil {
+		t.Error("expected an error for a non-numeric tag")
+	}
+}
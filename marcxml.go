@@ -0,0 +1,175 @@
+package fml
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+// marcXMLNamespace is the namespace used by the MARCXML ("MARC21 slim")
+// schema.
+const marcXMLNamespace = "http://www.loc.gov/MARC21/slim"
+
+// MarcXMLIterator will iterate over a set of MARC records encoded as
+// MARCXML using the Next() and Value() methods. Use the
+// NewMarcXMLIterator function to create a MarcXMLIterator.
+type MarcXMLIterator struct {
+	decoder *xml.Decoder
+	current marcXMLRecord
+	err     error
+}
+
+// NewMarcXMLIterator creates and returns a new instance of a
+// MarcXMLIterator. This function should be used to create a
+// MarcXMLIterator rather than instantiating one yourself.
+func NewMarcXMLIterator(r io.Reader) *MarcXMLIterator {
+	return &MarcXMLIterator{decoder: xml.NewDecoder(r)}
+}
+
+// Next advances the MarcXMLIterator to the next record, which will be
+// available through the Value method. It returns false when the
+// MarcXMLIterator has reached the end of the document or has encountered
+// an error. Any error will be accessible from the Err method.
+func (m *MarcXMLIterator) Next() bool {
+	for {
+		tok, err := m.decoder.Token()
+		if err == io.EOF {
+			return false
+		}
+		if err != nil {
+			m.err = err
+			return false
+		}
+		se, ok := tok.(xml.StartElement)
+		if !ok || se.Name.Local != "record" {
+			continue
+		}
+		var rec marcXMLRecord
+		if err := m.decoder.DecodeElement(&rec, &se); err != nil {
+			m.err = err
+			return false
+		}
+		m.current = rec
+		return true
+	}
+}
+
+// Value returns the current Record or the MarcXMLIterator.
+func (m *MarcXMLIterator) Value() (Record, error) {
+	return m.current.toRecord()
+}
+
+// Err will return the first error encountered by the MarcXMLIterator.
+func (m *MarcXMLIterator) Err() error {
+	return m.err
+}
+
+// marcXMLRecord mirrors the <record> element of the MARCXML schema.
+type marcXMLRecord struct {
+	XMLName       xml.Name              `xml:"record"`
+	Leader        string                `xml:"leader"`
+	ControlFields []marcXMLControlField `xml:"controlfield"`
+	DataFields    []marcXMLDataField    `xml:"datafield"`
+}
+
+type marcXMLControlField struct {
+	Tag   string `xml:"tag,attr"`
+	Value string `xml:",chardata"`
+}
+
+type marcXMLDataField struct {
+	Tag        string            `xml:"tag,attr"`
+	Indicator1 string            `xml:"ind1,attr"`
+	Indicator2 string            `xml:"ind2,attr"`
+	SubFields  []marcXMLSubField `xml:"subfield"`
+}
+
+type marcXMLSubField struct {
+	Code  string `xml:"code,attr"`
+	Value string `xml:",chardata"`
+}
+
+// toRecord converts the decoded XML into a Record using the same Leader,
+// ControlField, DataField and SubField types as the binary reader, so
+// downstream code such as Filter works unchanged. MARCXML leaders commonly
+// omit the computed length bytes (00-04, 12-16), so a short leader is
+// padded with spaces rather than treated as an error.
+func (x marcXMLRecord) toRecord() (Record, error) {
+	rec := Record{}
+	leader := x.Leader
+	if len(leader) < 24 {
+		leader += strings.Repeat(" ", 24-len(leader))
+	}
+	rec.Leader = leaderFromBytes([]byte(leader))
+
+	for _, cf := range x.ControlFields {
+		rec.Fields = append(rec.Fields, ControlField{Tag: cf.Tag, Value: cf.Value})
+	}
+	for _, df := range x.DataFields {
+		d := DataField{Tag: df.Tag, Indicator1: df.Indicator1, Indicator2: df.Indicator2}
+		for _, sf := range df.SubFields {
+			d.SubFields = append(d.SubFields, SubField{Code: sf.Code, Value: sf.Value})
+		}
+		rec.Fields = append(rec.Fields, d)
+	}
+	return rec, nil
+}
+
+// recordToMarcXML converts a Record into its MARCXML representation.
+func recordToMarcXML(r Record) marcXMLRecord {
+	x := marcXMLRecord{
+		XMLName: xml.Name{Space: marcXMLNamespace, Local: "record"},
+		Leader:  string(leaderBytes(r)),
+	}
+	for _, f := range r.Fields {
+		switch field := f.(type) {
+		case ControlField:
+			x.ControlFields = append(x.ControlFields, marcXMLControlField{Tag: field.Tag, Value: field.Value})
+		case DataField:
+			df := marcXMLDataField{Tag: field.Tag, Indicator1: field.Indicator1, Indicator2: field.Indicator2}
+			for _, sf := range field.SubFields {
+				df.SubFields = append(df.SubFields, marcXMLSubField{Code: sf.Code, Value: sf.Value})
+			}
+			x.DataFields = append(x.DataFields, df)
+		}
+	}
+	return x
+}
+
+// WriteMarcXML writes a single Record to w as a standalone MARCXML
+// <record> element.
+func WriteMarcXML(w io.Writer, r Record) error {
+	return xml.NewEncoder(w).Encode(recordToMarcXML(r))
+}
+
+// MarcXMLCollectionWriter wraps a sequence of records in a MARCXML
+// <collection> envelope. Use NewMarcXMLCollectionWriter to create one,
+// call WriteRecord for each Record, then Close to emit the closing tag.
+type MarcXMLCollectionWriter struct {
+	w   io.Writer
+	enc *xml.Encoder
+}
+
+// NewMarcXMLCollectionWriter writes the MARCXML collection envelope's
+// opening tags to w and returns a writer ready to accept records.
+func NewMarcXMLCollectionWriter(w io.Writer) (*MarcXMLCollectionWriter, error) {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(w, `<collection xmlns="`+marcXMLNamespace+`">`+"\n"); err != nil {
+		return nil, err
+	}
+	return &MarcXMLCollectionWriter{w: w, enc: xml.NewEncoder(w)}, nil
+}
+
+// WriteRecord writes a single Record as a <record> element inside the
+// collection envelope.
+func (c *MarcXMLCollectionWriter) WriteRecord(r Record) error {
+	return c.enc.Encode(recordToMarcXML(r))
+}
+
+// Close writes the collection envelope's closing tag.
+func (c *MarcXMLCollectionWriter) Close() error {
+	_, err := io.WriteString(c.w, "</collection>\n")
+	return err
+}
@@ -0,0 +1,101 @@
+package fml
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const xmlFixture = `<?xml version="1.0" encoding="UTF-8"?>
+<collection xmlns="http://www.loc.gov/MARC21/slim">
+  <record>
+    <leader>00000cam a2200000 a 4500</leader>
+    <controlfield tag="001">92005291</controlfield>
+    <datafield tag="245" ind1="1" ind2="0">
+      <subfield code="a">Title :</subfield>
+      <subfield code="b">subtitle /</subfield>
+    </datafield>
+  </record>
+  <record>
+    <leader>a</leader>
+    <controlfield tag="001">92005292</controlfield>
+  </record>
+</collection>`
+
+func TestMarcXMLIterator(t *testing.T) {
+	iter := NewMarcXMLIterator(strings.NewReader(xmlFixture))
+
+	t.Run("First record", func(t *testing.T) {
+		if !iter.Next() {
+			t.Fatal("expected a record")
+		}
+		r, err := iter.Value()
+		if err != nil {
+			t.Error(err)
+		}
+		if r.ControlNum() != "92005291" {
+			t.Error("Expected 92005291, got", r.ControlNum())
+		}
+		if r.Leader.Type != 'a' {
+			t.Error("Expected a, got", r.Leader.Type)
+		}
+		sfs := r.Filter("245ab")
+		if len(sfs) != 1 || strings.Join(sfs[0], " ") != "Title : subtitle /" {
+			t.Error("Expected Title : subtitle /, got", sfs)
+		}
+	})
+
+	t.Run("Short leader is padded", func(t *testing.T) {
+		if !iter.Next() {
+			t.Fatal("expected a second record")
+		}
+		r, err := iter.Value()
+		if err != nil {
+			t.Error(err)
+		}
+		if r.Leader.Type != 0x20 {
+			t.Error("Expected padded space, got", r.Leader.Type)
+		}
+	})
+
+	t.Run("End of document", func(t *testing.T) {
+		if iter.Next() {
+			t.Error("expected no more records")
+		}
+		if iter.Err() != nil {
+			t.Error(iter.Err())
+		}
+	})
+}
+
+func TestWriteMarcXML(t *testing.T) {
+	rec := Record{
+		Leader: Leader{Type: 'a'},
+		Fields: []interface{}{
+			ControlField{Tag: "001", Value: "92005291"},
+			DataField{
+				Tag:        "245",
+				Indicator1: "1",
+				Indicator2: "0",
+				SubFields:  []SubField{{Code: "a", Value: "Title /"}},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteMarcXML(&buf, rec); err != nil {
+		t.Fatal(err)
+	}
+
+	iter := NewMarcXMLIterator(&buf)
+	if !iter.Next() {
+		t.Fatal("expected to read back the written record")
+	}
+	r, err := iter.Value()
+	if err != nil {
+		t.Error(err)
+	}
+	if r.ControlNum() != "92005291" {
+		t.Error("Expected 92005291, got", r.ControlNum())
+	}
+}
@@ -0,0 +1,177 @@
+package fml
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Result holds the outcome of processing a single Record with
+// ProcessOrdered: either the value fn produced, or the error encountered
+// while decoding or processing the record.
+type Result struct {
+	Value interface{}
+	Err   error
+}
+
+// Process fans the MarcIterator's remaining records out to workers
+// goroutines, calling fn at most once per record. The bufio.Scanner
+// backing the iterator is only ever read on the calling goroutine (scanner
+// buffers are not safe to share), so each record's bytes are copied into a
+// fresh slice before being handed to a worker.
+//
+// The first error returned by fn, or encountered while decoding a record,
+// cancels ctx and is returned once every worker has unwound. Records
+// already queued may still be processed after the error is recorded.
+func (m *MarcIterator) Process(ctx context.Context, workers int, fn func(Record) error) error {
+	if workers <= 0 {
+		return fmt.Errorf("workers must be greater than zero, got %d", workers)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan []byte, workers)
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for b := range jobs {
+				rec, err := m.scanIntoRecord(b)
+				if err != nil {
+					fail(err)
+					continue
+				}
+				if err := fn(rec); err != nil {
+					fail(err)
+				}
+			}
+		}()
+	}
+
+dispatch:
+	for m.Next() {
+		select {
+		case <-ctx.Done():
+			break dispatch
+		default:
+		}
+		b := append([]byte(nil), m.scanner.Bytes()...)
+		select {
+		case jobs <- b:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	if err := m.Err(); err != nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// ProcessOrdered behaves like Process but reassembles fn's results in
+// input order before returning them, using a small buffer keyed by each
+// record's sequence number to hold results that finish out of order. It
+// does not abort on the first error: every record is processed and given
+// a slot in the returned []Result, so callers can decide for themselves
+// whether a failed record should stop a downstream export or just be
+// skipped.
+func (m *MarcIterator) ProcessOrdered(ctx context.Context, workers int, fn func(Record) (interface{}, error)) ([]Result, error) {
+	if workers <= 0 {
+		return nil, fmt.Errorf("workers must be greater than zero, got %d", workers)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type job struct {
+		seq  int
+		data []byte
+	}
+	type indexed struct {
+		seq int
+		res Result
+	}
+
+	jobs := make(chan job, workers)
+	results := make(chan indexed, workers)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				rec, err := m.scanIntoRecord(j.data)
+				if err != nil {
+					results <- indexed{j.seq, Result{Err: err}}
+					continue
+				}
+				val, err := fn(rec)
+				results <- indexed{j.seq, Result{Value: val, Err: err}}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		seq := 0
+		for m.Next() {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+			b := append([]byte(nil), m.scanner.Bytes()...)
+			select {
+			case jobs <- job{seq, b}:
+				seq++
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	pending := make(map[int]Result)
+	next := 0
+	var ordered []Result
+	for r := range results {
+		pending[r.seq] = r.res
+		for {
+			res, ok := pending[next]
+			if !ok {
+				break
+			}
+			ordered = append(ordered, res)
+			delete(pending, next)
+			next++
+		}
+	}
+
+	if err := m.Err(); err != nil {
+		return ordered, err
+	}
+	return ordered, ctx.Err()
+}
@@ -0,0 +1,104 @@
+package fml
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func sampleDump(t *testing.T, n int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	for i := 0; i < n; i++ {
+		r := NewRecord(Leader{Type: 'a'})
+		r.AddControlField("001", fmt.Sprintf("%08d", i))
+		if _, err := r.WriteTo(&buf); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return buf.Bytes()
+}
+
+func TestMarcIteratorProcess(t *testing.T) {
+	dump := sampleDump(t, 20)
+	iter := NewMarcIterator(bytes.NewReader(dump))
+
+	var mu sync.Mutex
+	var seen []string
+	err := iter.Process(context.Background(), 4, func(r Record) error {
+		mu.Lock()
+		seen = append(seen, r.ControlNum())
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(seen) != 20 {
+		t.Error("Expected 20, got", len(seen))
+	}
+	sort.Strings(seen)
+	for i, v := range seen {
+		if v != fmt.Sprintf("%08d", i) {
+			t.Error("Missing or duplicate record", i, v)
+		}
+	}
+}
+
+func TestMarcIteratorProcessStopsOnError(t *testing.T) {
+	dump := sampleDump(t, 20)
+	iter := NewMarcIterator(bytes.NewReader(dump))
+
+	boom := errors.New("boom")
+	err := iter.Process(context.Background(), 2, func(r Record) error {
+		if r.ControlNum() == "00000005" {
+			return boom
+		}
+		return nil
+	})
+	if !errors.Is(err, boom) {
+		t.Error("Expected boom, got", err)
+	}
+}
+
+func TestMarcIteratorProcessStopsOnCanceledContext(t *testing.T) {
+	dump := sampleDump(t, 1000)
+	iter := NewMarcIterator(bytes.NewReader(dump))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := iter.Process(ctx, 4, func(r Record) error {
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Error("Expected context.Canceled, got", err)
+	}
+}
+
+func TestMarcIteratorProcessOrdered(t *testing.T) {
+	dump := sampleDump(t, 20)
+	iter := NewMarcIterator(bytes.NewReader(dump))
+
+	results, err := iter.ProcessOrdered(context.Background(), 4, func(r Record) (interface{}, error) {
+		return r.ControlNum(), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 20 {
+		t.Fatal("Expected 20, got", len(results))
+	}
+	for i, r := range results {
+		if r.Err != nil {
+			t.Error(r.Err)
+		}
+		if r.Value != fmt.Sprintf("%08d", i) {
+			t.Error("Expected in-order result, got", r.Value, "at", i)
+		}
+	}
+}